@@ -0,0 +1,39 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSafeJoinRejectsEscape(t *testing.T) {
+	destDir := filepath.Join(t.TempDir(), "dest")
+
+	cases := []struct {
+		name    string
+		entry   string
+		wantErr bool
+	}{
+		{name: "plain file", entry: "go/bin/go", wantErr: false},
+		{name: "parent traversal", entry: "../../etc/passwd", wantErr: true},
+		{name: "traversal disguised mid-path", entry: "go/../../etc/passwd", wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			target, err := safeJoin(destDir, c.entry)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("safeJoin(%q, %q) = %q, want error", destDir, c.entry, target)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("safeJoin(%q, %q) returned unexpected error: %v", destDir, c.entry, err)
+			}
+			want := filepath.Join(destDir, c.entry)
+			if target != want {
+				t.Errorf("safeJoin(%q, %q) = %q, want %q", destDir, c.entry, target, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"fmt"
+	"go/version"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+
+	"github.com/gonnafaraway/go-switcher/releases"
+	"github.com/spf13/cobra"
+)
+
+// goSourceRepo is the canonical Go source repository, mirrored on GitHub
+// but authoritative at googlesource.com.
+const goSourceRepo = "https://go.googlesource.com/go"
+
+// defaultBootstrapVersion is used to bootstrap a build when no suitable
+// toolchain is already installed. It satisfies the GOROOT_BOOTSTRAP
+// requirement (Go 1.20+) for every currently maintained release; building
+// genuinely ancient Go versions may require passing --bootstrap-version
+// 1.4 or 1.17 explicitly, per that release's bootstrap requirements.
+const defaultBootstrapVersion = "1.21.0"
+
+// refSanitizer matches runs of characters that aren't safe in a directory
+// name; buildGoFromSource replaces each run in a git ref (tag, branch, or
+// commit) with "-".
+var refSanitizer = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// srcDirName is the top-level directory, alongside version directories,
+// holding build's git-clone source checkouts (one per ref built). It's
+// excluded when walking config.GoDownloadDir for installed versions.
+const srcDirName = "src"
+
+// NewBuildCommand creates a new build command.
+func NewBuildCommand(config *Config) *Command {
+	cmd := &Command{
+		Command: &cobra.Command{
+			Use:   "build <ref>",
+			Short: "Build Go from source at a tag, branch, or commit",
+			Long:  "Clone the Go source tree at ref and run src/make.bash, using an installed toolchain (or a downloaded fallback) as GOROOT_BOOTSTRAP",
+			Args:  cobra.ExactArgs(1),
+		},
+		config: config,
+	}
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		if err := buildGoFromSource(cmd, config, args[0]); err != nil {
+			log.Fatalf("Failed to build Go from source: %v", err)
+		}
+	}
+
+	cmd.Flags().String("goos", runtime.GOOS, "GOOS to cross-compile the toolchain for")
+	cmd.Flags().String("goarch", runtime.GOARCH, "GOARCH to cross-compile the toolchain for")
+	cmd.Flags().String("goexperiment", "", "GOEXPERIMENT flags to build with (e.g. rangefunc)")
+	cmd.Flags().String("gcflags", "", "Extra -gcflags to pass to the build")
+	cmd.Flags().String("bootstrap-version", "", "Installed Go version to use as GOROOT_BOOTSTRAP (defaults to the newest installed version, or downloads "+defaultBootstrapVersion)
+
+	return cmd
+}
+
+// buildGoFromSource clones the Go source tree at ref, bootstraps it with an
+// existing (or freshly downloaded) toolchain, and registers the result as
+// an installed version named ref.
+func buildGoFromSource(cmd *cobra.Command, config *Config, ref string) error {
+	goos, _ := cmd.Flags().GetString("goos")
+	goarch, _ := cmd.Flags().GetString("goarch")
+	arch := goos + "-" + goarch
+	goexperiment, _ := cmd.Flags().GetString("goexperiment")
+	gcflags, _ := cmd.Flags().GetString("gcflags")
+	bootstrapVersion, _ := cmd.Flags().GetString("bootstrap-version")
+
+	srcDir := filepath.Join(config.GoDownloadDir, srcDirName, refSanitizer.ReplaceAllString(ref, "-"))
+
+	if err := fetchSource(srcDir, ref); err != nil {
+		return fmt.Errorf("fetch source: %w", err)
+	}
+
+	bootstrapRoot, err := resolveBootstrap(cmd, config, bootstrapVersion)
+	if err != nil {
+		return fmt.Errorf("resolve bootstrap toolchain: %w", err)
+	}
+
+	fmt.Printf("= Building Go from %s using bootstrap %s =\n", ref, bootstrapRoot)
+
+	if err := runMake(srcDir, bootstrapRoot, goos, goarch, goexperiment, gcflags); err != nil {
+		return fmt.Errorf("run make: %w", err)
+	}
+
+	versionDir := filepath.Join(config.GoDownloadDir, ref, arch)
+	if err := os.MkdirAll(versionDir, 0755); err != nil {
+		return fmt.Errorf("create version directory: %w", err)
+	}
+
+	goLink := filepath.Join(versionDir, "go")
+	if _, err := os.Lstat(goLink); os.IsNotExist(err) {
+		if err := os.Symlink(srcDir, goLink); err != nil {
+			return fmt.Errorf("link built toolchain: %w", err)
+		}
+	}
+
+	fmt.Printf("= Built %s successfully, installed as version %q =\n", ref, ref)
+	return nil
+}
+
+// fetchSource clones (or updates an existing clone of) the Go source
+// repository into srcDir and checks out ref.
+func fetchSource(srcDir, ref string) error {
+	if _, err := os.Stat(filepath.Join(srcDir, ".git")); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(srcDir), 0755); err != nil {
+			return fmt.Errorf("create source directory: %w", err)
+		}
+		if err := runCommand("", "git", "clone", goSourceRepo, srcDir); err != nil {
+			return fmt.Errorf("clone %s: %w", goSourceRepo, err)
+		}
+	} else {
+		if err := runCommand(srcDir, "git", "fetch", "origin"); err != nil {
+			return fmt.Errorf("fetch updates: %w", err)
+		}
+	}
+
+	if err := runCommand(srcDir, "git", "checkout", ref); err != nil {
+		return fmt.Errorf("checkout %s: %w", ref, err)
+	}
+
+	return nil
+}
+
+// resolveBootstrap returns the GOROOT of a toolchain suitable for
+// GOROOT_BOOTSTRAP: the version named by bootstrapVersion if given,
+// otherwise the newest already-installed version, otherwise a freshly
+// downloaded defaultBootstrapVersion.
+func resolveBootstrap(cmd *cobra.Command, config *Config, bootstrapVersion string) (string, error) {
+	arch := defaultArch()
+
+	if bootstrapVersion != "" {
+		paths, err := resolveGoPaths(config, bootstrapVersion, arch)
+		if err != nil {
+			return "", err
+		}
+		return paths.goRoot, nil
+	}
+
+	if newest := newestInstalledVersion(config, arch); newest != "" {
+		paths, err := resolveGoPaths(config, newest, arch)
+		if err == nil {
+			return paths.goRoot, nil
+		}
+	}
+
+	fmt.Printf("= No suitable bootstrap toolchain installed, downloading Go %s =\n", defaultBootstrapVersion)
+	if err := fetchAndInstall(config, defaultBootstrapVersion, arch, false); err != nil {
+		return "", fmt.Errorf("download bootstrap toolchain: %w", err)
+	}
+
+	paths, err := resolveGoPaths(config, defaultBootstrapVersion, arch)
+	if err != nil {
+		return "", err
+	}
+	return paths.goRoot, nil
+}
+
+// newestInstalledVersion returns the newest installed version for arch,
+// comparing names as Go versions (with or without the "go" prefix), so
+// "go1.21" is newer than "go1.9". Names that aren't Go versions (e.g. a
+// built branch) rank below every release.
+func newestInstalledVersion(config *Config, arch string) string {
+	dir, err := os.ReadDir(config.GoDownloadDir)
+	if err != nil {
+		return ""
+	}
+
+	var newest string
+	for _, fi := range dir {
+		if !fi.IsDir() || isReservedDirName(fi.Name()) {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(config.GoDownloadDir, fi.Name(), arch)); err != nil {
+			continue
+		}
+		if newest == "" || version.Compare(releases.NormalizeVersion(fi.Name()), releases.NormalizeVersion(newest)) > 0 {
+			newest = fi.Name()
+		}
+	}
+
+	return newest
+}
+
+// runMake invokes src/make.bash (or make.bat on Windows) inside srcDir/src
+// with the given cross-compilation and experiment settings.
+func runMake(srcDir, bootstrapRoot, goos, goarch, goexperiment, gcflags string) error {
+	script := "./make.bash"
+	if runtime.GOOS == "windows" {
+		script = "make.bat"
+	}
+
+	env := append(os.Environ(),
+		"GOROOT_BOOTSTRAP="+bootstrapRoot,
+		"GOOS="+goos,
+		"GOARCH="+goarch,
+	)
+	if goexperiment != "" {
+		env = append(env, "GOEXPERIMENT="+goexperiment)
+	}
+	if gcflags != "" {
+		env = append(env, "GOFLAGS=-gcflags="+gcflags)
+	}
+
+	c := exec.Command(script)
+	c.Dir = filepath.Join(srcDir, "src")
+	c.Env = env
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+
+	return c.Run()
+}
+
+// runCommand runs name with args, streaming output to the console, in dir
+// (the current directory if dir is empty).
+func runCommand(dir, name string, args ...string) error {
+	c := exec.Command(name, args...)
+	c.Dir = dir
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	return c.Run()
+}
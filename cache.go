@@ -0,0 +1,396 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// storeDirName is the top-level directory, alongside version directories,
+// holding the content-addressed store. It's excluded when walking
+// config.GoDownloadDir for installed versions.
+const storeDirName = "store"
+
+// reservedDirNames are the top-level entries under config.GoDownloadDir
+// that are never version directories: the content-addressed store
+// (storeDirName), the shim scripts (shimsDirName), and build's git-clone
+// source checkouts (srcDirName). Every loop that walks config.GoDownloadDir
+// looking for installed versions must skip these.
+var reservedDirNames = map[string]bool{
+	storeDirName: true,
+	shimsDirName: true,
+	srcDirName:   true,
+}
+
+// isReservedDirName reports whether name is a top-level directory under
+// config.GoDownloadDir that isn't a version directory.
+func isReservedDirName(name string) bool {
+	return reservedDirNames[name]
+}
+
+// storeMetadataFileName records where a store entry came from, so `cache
+// verify` can detect corruption and repair it by re-downloading.
+const storeMetadataFileName = ".go-switcher-source.json"
+
+// storeMetadata is the sidecar file written alongside each extracted
+// store entry.
+type storeMetadata struct {
+	Version    string `json:"version"`
+	Arch       string `json:"arch"`
+	Filename   string `json:"filename"`
+	SHA256     string `json:"sha256"`
+	TreeDigest string `json:"tree_digest"`
+}
+
+// storeRoot returns <root>/store/sha256, mirroring cmd/go's build cache
+// layout of a fixed hash-algorithm directory under the cache root.
+func storeRoot(config *Config) string {
+	return filepath.Join(config.GoDownloadDir, storeDirName, "sha256")
+}
+
+// installToStore extracts the archive at archivePath into the
+// content-addressed store, keyed by its SHA256, unless an entry already
+// exists there (archives are deduplicated across versions/architectures
+// that happen to be byte-identical, and re-downloads of the same
+// version/arch are idempotent). It returns the store directory.
+func installToStore(config *Config, archivePath, sha256Sum, version, arch, filename string) (string, error) {
+	storeDir := filepath.Join(storeRoot(config), sha256Sum)
+
+	if _, err := os.Stat(filepath.Join(storeDir, storeMetadataFileName)); err == nil {
+		return storeDir, nil
+	}
+
+	if err := os.RemoveAll(storeDir); err != nil {
+		return "", fmt.Errorf("clear stale store entry: %w", err)
+	}
+	if err := os.MkdirAll(storeDir, 0755); err != nil {
+		return "", fmt.Errorf("create store entry: %w", err)
+	}
+
+	if err := extractArchive(archivePath, storeDir); err != nil {
+		return "", fmt.Errorf("extract into store: %w", err)
+	}
+
+	digest, err := computeTreeDigest(storeDir)
+	if err != nil {
+		return "", fmt.Errorf("digest extracted tree: %w", err)
+	}
+
+	meta := storeMetadata{
+		Version:    version,
+		Arch:       arch,
+		Filename:   filename,
+		SHA256:     sha256Sum,
+		TreeDigest: digest,
+	}
+	if err := writeStoreMetadata(storeDir, meta); err != nil {
+		return "", err
+	}
+
+	return storeDir, nil
+}
+
+// linkVersion points <root>/<version>/<arch> at storeDir, replacing
+// whatever was there before.
+func linkVersion(config *Config, version, arch, storeDir string) error {
+	versionDir := filepath.Join(config.GoDownloadDir, version)
+	if err := os.MkdirAll(versionDir, 0755); err != nil {
+		return fmt.Errorf("create version directory: %w", err)
+	}
+
+	archLink := filepath.Join(versionDir, arch)
+	if err := os.RemoveAll(archLink); err != nil {
+		return fmt.Errorf("remove existing link: %w", err)
+	}
+
+	return os.Symlink(storeDir, archLink)
+}
+
+func writeStoreMetadata(storeDir string, meta storeMetadata) error {
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal store metadata: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(storeDir, storeMetadataFileName), data, 0644); err != nil {
+		return fmt.Errorf("write store metadata: %w", err)
+	}
+	return nil
+}
+
+func readStoreMetadata(storeDir string) (*storeMetadata, error) {
+	data, err := os.ReadFile(filepath.Join(storeDir, storeMetadataFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	var meta storeMetadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("parse store metadata: %w", err)
+	}
+
+	return &meta, nil
+}
+
+// computeTreeDigest hashes the relative path and content of every regular
+// file under dir, in a stable order, producing a single digest that
+// changes if the tree is corrupted, truncated, or tampered with.
+func computeTreeDigest(dir string) (string, error) {
+	h := sha256.New()
+
+	var paths []string
+	if err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type()&os.ModeSymlink != 0 || !d.Type().IsRegular() {
+			return nil
+		}
+		if d.Name() == storeMetadataFileName {
+			return nil
+		}
+		paths = append(paths, path)
+		return nil
+	}); err != nil {
+		return "", err
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintln(h, rel)
+
+		f, err := os.Open(path)
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// NewCacheCommand creates the parent "cache" command.
+func NewCacheCommand(config *Config) *Command {
+	cmd := &Command{
+		Command: &cobra.Command{
+			Use:   "cache",
+			Short: "Manage the content-addressed download cache",
+			Long:  "Inspect, garbage-collect, and verify the content-addressed store that installed toolchains are extracted into",
+		},
+		config: config,
+	}
+
+	cmd.AddCommand(
+		NewCacheGCCommand(config).Command,
+		NewCacheVerifyCommand(config).Command,
+	)
+
+	return cmd
+}
+
+// NewCacheGCCommand creates the "cache gc" command.
+func NewCacheGCCommand(config *Config) *Command {
+	cmd := &Command{
+		Command: &cobra.Command{
+			Use:   "gc",
+			Short: "Delete unreferenced store entries",
+			Long:  "Delete store entries that no installed version/arch links to, keeping the N most recently modified and skipping anything younger than --older-than",
+		},
+		config: config,
+	}
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		keep, _ := cmd.Flags().GetInt("keep")
+		olderThan, _ := cmd.Flags().GetDuration("older-than")
+		if err := cacheGC(config, keep, olderThan); err != nil {
+			log.Fatalf("Failed to garbage-collect cache: %v", err)
+		}
+	}
+
+	cmd.Flags().Int("keep", 5, "Always keep this many unreferenced entries, newest first")
+	cmd.Flags().Duration("older-than", 30*24*time.Hour, "Only delete unreferenced entries older than this (e.g. 720h for 30 days)")
+
+	return cmd
+}
+
+// NewCacheVerifyCommand creates the "cache verify" command.
+func NewCacheVerifyCommand(config *Config) *Command {
+	cmd := &Command{
+		Command: &cobra.Command{
+			Use:   "verify",
+			Short: "Re-hash store entries and repair corruption",
+			Long:  "Re-hash every store entry's extracted tree against its recorded digest, re-downloading any entry that doesn't match",
+		},
+		config: config,
+	}
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		if err := cacheVerify(config); err != nil {
+			log.Fatalf("Failed to verify cache: %v", err)
+		}
+	}
+
+	return cmd
+}
+
+// referencedStoreDirs returns the set of store directories (resolved,
+// absolute paths) that some <root>/<version>/<arch> symlink points to.
+func referencedStoreDirs(config *Config) (map[string]bool, error) {
+	referenced := make(map[string]bool)
+
+	dir, err := os.ReadDir(config.GoDownloadDir)
+	if err != nil {
+		return nil, fmt.Errorf("read directory: %w", err)
+	}
+
+	for _, fi := range dir {
+		if !fi.IsDir() || isReservedDirName(fi.Name()) {
+			continue
+		}
+
+		versionDir := filepath.Join(config.GoDownloadDir, fi.Name())
+		archDirs, err := os.ReadDir(versionDir)
+		if err != nil {
+			continue
+		}
+
+		for _, archFi := range archDirs {
+			target, err := filepath.EvalSymlinks(filepath.Join(versionDir, archFi.Name()))
+			if err != nil {
+				continue
+			}
+			referenced[target] = true
+		}
+	}
+
+	return referenced, nil
+}
+
+// cacheGC deletes store entries not referenced by any installed
+// version/arch, always keeping the `keep` most recently modified such
+// entries and skipping any younger than olderThan.
+func cacheGC(config *Config, keep int, olderThan time.Duration) error {
+	referenced, err := referencedStoreDirs(config)
+	if err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(storeRoot(config))
+	if os.IsNotExist(err) {
+		fmt.Println("No cache entries found")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read store: %w", err)
+	}
+
+	type candidate struct {
+		path    string
+		modTime time.Time
+	}
+
+	var unreferenced []candidate
+	for _, entry := range entries {
+		path := filepath.Join(storeRoot(config), entry.Name())
+		resolved, err := filepath.EvalSymlinks(path)
+		if err != nil {
+			resolved = path
+		}
+		if referenced[resolved] {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		unreferenced = append(unreferenced, candidate{path: path, modTime: info.ModTime()})
+	}
+
+	sort.Slice(unreferenced, func(i, j int) bool {
+		return unreferenced[i].modTime.After(unreferenced[j].modTime)
+	})
+
+	if keep > len(unreferenced) {
+		keep = len(unreferenced)
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	var removed int
+
+	for _, c := range unreferenced[keep:] {
+		if c.modTime.After(cutoff) {
+			continue
+		}
+
+		fmt.Printf("Removing unreferenced cache entry: %s\n", filepath.Base(c.path))
+		if err := os.RemoveAll(c.path); err != nil {
+			log.Printf("Warning: failed to remove %s: %v", c.path, err)
+			continue
+		}
+		removed++
+	}
+
+	fmt.Printf("= Garbage collection complete: removed %d of %d unreferenced entries =\n", removed, len(unreferenced))
+	return nil
+}
+
+// cacheVerify re-hashes every store entry's extracted tree and
+// re-downloads any that don't match their recorded digest.
+func cacheVerify(config *Config) error {
+	entries, err := os.ReadDir(storeRoot(config))
+	if os.IsNotExist(err) {
+		fmt.Println("No cache entries found")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read store: %w", err)
+	}
+
+	for _, entry := range entries {
+		storeDir := filepath.Join(storeRoot(config), entry.Name())
+
+		meta, err := readStoreMetadata(storeDir)
+		if err != nil {
+			log.Printf("Warning: %s has no metadata, skipping: %v", entry.Name(), err)
+			continue
+		}
+
+		digest, err := computeTreeDigest(storeDir)
+		if err == nil && digest == meta.TreeDigest {
+			fmt.Printf("OK   %s (%s/%s)\n", entry.Name(), meta.Version, meta.Arch)
+			continue
+		}
+
+		fmt.Printf("BAD  %s (%s/%s): re-downloading\n", entry.Name(), meta.Version, meta.Arch)
+
+		if err := os.RemoveAll(storeDir); err != nil {
+			log.Printf("Warning: failed to remove corrupted entry %s: %v", entry.Name(), err)
+			continue
+		}
+
+		if err := fetchAndInstall(config, meta.Version, meta.Arch, false); err != nil {
+			log.Printf("Warning: failed to repair %s: %v", entry.Name(), err)
+		}
+	}
+
+	return nil
+}
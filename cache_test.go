@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// makeStoreEntry creates an empty store directory named name with the given
+// age, so cacheGC has something to sort and filter on.
+func makeStoreEntry(t *testing.T, config *Config, name string, age time.Duration) string {
+	t.Helper()
+
+	dir := filepath.Join(storeRoot(config), name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("create store entry %s: %v", name, err)
+	}
+
+	modTime := time.Now().Add(-age)
+	if err := os.Chtimes(dir, modTime, modTime); err != nil {
+		t.Fatalf("set mtime for %s: %v", name, err)
+	}
+
+	return dir
+}
+
+func TestCacheGCKeepsReferencedAndRecentEntries(t *testing.T) {
+	config := &Config{GoDownloadDir: t.TempDir()}
+
+	referenced := makeStoreEntry(t, config, "referenced", 48*time.Hour)
+	recent := makeStoreEntry(t, config, "recent", 10*time.Minute)
+	old1 := makeStoreEntry(t, config, "old1", 2*time.Hour)
+	old2 := makeStoreEntry(t, config, "old2", 3*time.Hour)
+
+	if err := linkVersion(config, "go1.99", "linux-amd64", referenced); err != nil {
+		t.Fatalf("link referenced version: %v", err)
+	}
+
+	if err := cacheGC(config, 1, time.Hour); err != nil {
+		t.Fatalf("cacheGC: %v", err)
+	}
+
+	for _, want := range []string{referenced, recent} {
+		if _, err := os.Stat(want); err != nil {
+			t.Errorf("expected %s to survive gc, got: %v", want, err)
+		}
+	}
+	for _, want := range []string{old1, old2} {
+		if _, err := os.Stat(want); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed by gc, stat err: %v", want, err)
+		}
+	}
+}
+
+func TestCacheGCSkipsEntriesYoungerThanOlderThan(t *testing.T) {
+	config := &Config{GoDownloadDir: t.TempDir()}
+
+	recent := makeStoreEntry(t, config, "recent", time.Minute)
+
+	if err := cacheGC(config, 0, time.Hour); err != nil {
+		t.Fatalf("cacheGC: %v", err)
+	}
+
+	if _, err := os.Stat(recent); err != nil {
+		t.Errorf("expected %s younger than --older-than to survive gc, got: %v", recent, err)
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// defaultInstallRoot computes the OS-appropriate default location for
+// go-switcher's downloaded toolchains. GO_SWITCHER_HOME always overrides it.
+func defaultInstallRoot() string {
+	if home := os.Getenv("GO_SWITCHER_HOME"); home != "" {
+		return home
+	}
+
+	switch runtime.GOOS {
+	case "windows":
+		if appData := os.Getenv("LOCALAPPDATA"); appData != "" {
+			return filepath.Join(appData, "go-switcher")
+		}
+	case "darwin":
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(homeDir, "Library", "Application Support", "go-switcher")
+		}
+	default:
+		if xdg := os.Getenv("XDG_DATA_HOME"); xdg != "" {
+			return filepath.Join(xdg, "go-switcher")
+		}
+	}
+
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(homeDir, ".local", "share", "go-switcher")
+	}
+
+	return filepath.Join(os.TempDir(), "go-switcher")
+}
+
+// defaultArch returns the "os-arch" pair for the host, e.g. "linux-amd64".
+func defaultArch() string {
+	return runtime.GOOS + "-" + runtime.GOARCH
+}
+
+// isDirOrLinkToDir reports whether path is a directory, or a symlink that
+// resolves to one. Arch entries under a version directory are symlinks
+// into the content-addressed store, so a plain DirEntry.IsDir() (which
+// doesn't follow symlinks) isn't enough to recognize them.
+func isDirOrLinkToDir(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.IsDir()
+}
+
+// normalizeArch accepts either a bare arch (e.g. "amd64"), which is
+// resolved against the host OS, or an explicit "os-arch" pair, and returns
+// the "os-arch" form. This lets `--arch arm64` work on whichever host OS
+// the tool is run from instead of only ever meaning "linux-arm64".
+func normalizeArch(arch string) string {
+	if strings.Contains(arch, "-") {
+		return arch
+	}
+	return runtime.GOOS + "-" + arch
+}
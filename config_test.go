@@ -0,0 +1,24 @@
+package main
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestNormalizeArch(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{in: "linux-amd64", want: "linux-amd64"},
+		{in: "darwin-arm64", want: "darwin-arm64"},
+		{in: "amd64", want: runtime.GOOS + "-amd64"},
+		{in: "arm64", want: runtime.GOOS + "-arm64"},
+	}
+
+	for _, c := range cases {
+		if got := normalizeArch(c.in); got != c.want {
+			t.Errorf("normalizeArch(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gonnafaraway/go-switcher/releases"
+	"github.com/spf13/cobra"
+)
+
+// listRemoteGoVersions prints the Go versions available from the official
+// release index, optionally filtered by os/arch/stability.
+func listRemoteGoVersions(cmd *cobra.Command) error {
+	all, _ := cmd.Flags().GetBool("all")
+	goos, _ := cmd.Flags().GetString("os")
+	goarch, _ := cmd.Flags().GetString("arch")
+	stableOnly, _ := cmd.Flags().GetBool("stable")
+
+	list, err := releases.Fetch(all)
+	if err != nil {
+		return fmt.Errorf("fetch release index: %w", err)
+	}
+
+	filtered := releases.Filter(list, goos, goarch, stableOnly)
+
+	fmt.Printf("= Go versions available from %s =\n", releases.IndexURL)
+
+	for _, release := range filtered {
+		for _, file := range release.Files {
+			fmt.Printf("%-12s %-4s/%-7s  %-8s  %10d bytes  sha256:%s\n",
+				release.Version, file.OS, file.Arch, file.Kind, file.Size, file.SHA256)
+		}
+	}
+
+	return nil
+}
@@ -4,17 +4,12 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"strconv"
+	"path/filepath"
 	"strings"
 
-	"github.com/magefile/mage/sh"
 	"github.com/spf13/cobra"
 )
 
-const (
-	goDownloadDir = "/usr/local/bin/go-switcher"
-)
-
 // Command represents a CLI command with its configuration and execution logic.
 type Command struct {
 	*cobra.Command
@@ -26,10 +21,11 @@ type Config struct {
 	GoDownloadDir string
 }
 
-// NewConfig creates a new configuration instance.
+// NewConfig creates a new configuration instance, using an OS-appropriate
+// default install root (overridable via GO_SWITCHER_HOME).
 func NewConfig() *Config {
 	return &Config{
-		GoDownloadDir: goDownloadDir,
+		GoDownloadDir: defaultInstallRoot(),
 	}
 }
 
@@ -61,6 +57,31 @@ func NewListCommand(config *Config) *Command {
 	return cmd
 }
 
+// NewListRemoteCommand creates a new list-remote command.
+func NewListRemoteCommand(config *Config) *Command {
+	cmd := &Command{
+		Command: &cobra.Command{
+			Use:   "list-remote",
+			Short: "List Go versions available for download",
+			Long:  "Query the official go.dev release index for available Go versions",
+		},
+		config: config,
+	}
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		if err := listRemoteGoVersions(cmd); err != nil {
+			log.Fatalf("Failed to list remote Go versions: %v", err)
+		}
+	}
+
+	cmd.Flags().Bool("all", false, "Include unstable and archived releases")
+	cmd.Flags().String("os", "", "Filter by OS (e.g. linux, darwin, windows)")
+	cmd.Flags().String("arch", "", "Filter by architecture (e.g. amd64, arm64)")
+	cmd.Flags().Bool("stable", false, "Only show stable releases")
+
+	return cmd
+}
+
 // NewDownloadCommand creates a new download command.
 func NewDownloadCommand(config *Config) *Command {
 	cmd := &Command{
@@ -79,7 +100,8 @@ func NewDownloadCommand(config *Config) *Command {
 		}
 	}
 
-	cmd.Flags().String("arch", "linux-amd64", "Architecture to download (e.g., linux-amd64, darwin-amd64, windows-amd64)")
+	cmd.Flags().String("arch", defaultArch(), "Architecture to download (e.g., linux-amd64, darwin-arm64, windows-amd64)")
+	cmd.Flags().Bool("insecure-skip-verify", false, "Skip SHA256 checksum verification")
 
 	return cmd
 }
@@ -110,23 +132,54 @@ func NewSwitchCommand(config *Config) *Command {
 		Command: &cobra.Command{
 			Use:   "switch [version|number]",
 			Short: "Switch Go version",
-			Long:  "Switch to a specific Go version by updating environment variables",
-			Args:  cobra.MinimumNArgs(1),
+			Long:  "Switch to a specific Go version by updating environment variables, or detect one with --auto",
 		},
 		config: config,
 	}
 
+	cmd.Args = func(cmd *cobra.Command, args []string) error {
+		auto, _ := cmd.Flags().GetBool("auto")
+		if auto {
+			return cobra.MaximumNArgs(0)(cmd, args)
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	}
+
 	cmd.Run = func(cmd *cobra.Command, args []string) {
+		auto, _ := cmd.Flags().GetBool("auto")
+		if auto {
+			if err := useGoVersion(cmd, config); err != nil {
+				log.Fatalf("Failed to auto-switch Go version: %v", err)
+			}
+			return
+		}
+
 		if err := switchGoVersion(cmd, config, args[0]); err != nil {
 			log.Fatalf("Failed to switch Go version: %v", err)
 		}
 	}
 
-	cmd.Flags().String("arch", "linux-amd64", "Architecture to switch to (e.g., linux-amd64, darwin-amd64, windows-amd64)")
+	cmd.Flags().String("arch", defaultArch(), "Architecture to switch to (e.g., linux-amd64, darwin-arm64, windows-amd64)")
+	cmd.Flags().Bool("auto", false, "Detect the version from .go-version/go.mod in the current directory (like `use`) instead of taking it as an argument")
+	cmd.Flags().Bool("no-download", false, "With --auto, fail instead of downloading the pinned version if it isn't installed")
+	cmd.Flags().Bool("print", false, "Print GOROOT/GOPATH/PATH to stdout instead of writing the shell profile, for `eval $(go-switcher switch ... --print)`")
+	cmd.Flags().Bool("print-env", false, "Print a machine-readable env block (KEY=VALUE lines, or JSON with --format json) instead of writing the shell profile")
+	cmd.Flags().String("format", "env", "Format for --print-env: env or json")
 
 	return cmd
 }
 
+// splitArch splits an "os-arch" flag value (e.g. "linux-amd64") into its
+// os and arch components. Bare archs (e.g. "arm64") are first normalized
+// against the host OS.
+func splitArch(arch string) (goos, goarch string, err error) {
+	parts := strings.SplitN(normalizeArch(arch), "-", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid arch %q, expected format os-arch (e.g. linux-amd64)", arch)
+	}
+	return parts[0], parts[1], nil
+}
+
 // listGoVersions lists all downloaded Go versions.
 func listGoVersions(cmd *cobra.Command, config *Config) error {
 	fmt.Printf("= Go versions from %s =\n", config.GoDownloadDir)
@@ -148,11 +201,11 @@ func listGoVersions(cmd *cobra.Command, config *Config) error {
 	)
 
 	for _, fi := range dir {
-		if !fi.IsDir() {
+		if !fi.IsDir() || isReservedDirName(fi.Name()) {
 			continue
 		}
 
-		versionDir := fmt.Sprintf("%s/%s", config.GoDownloadDir, fi.Name())
+		versionDir := filepath.Join(config.GoDownloadDir, fi.Name())
 		archDirs, err := os.ReadDir(versionDir)
 		if err != nil {
 			fmt.Printf("Error reading version directory %s: %v\n", fi.Name(), err)
@@ -160,10 +213,11 @@ func listGoVersions(cmd *cobra.Command, config *Config) error {
 		}
 
 		for _, archFi := range archDirs {
-			if archFi.IsDir() {
+			archPath := filepath.Join(config.GoDownloadDir, fi.Name(), archFi.Name())
+			if isDirOrLinkToDir(archPath) {
 				versions = append(versions, fi.Name())
 				architectures = append(architectures, archFi.Name())
-				paths = append(paths, fmt.Sprintf("%s/%s/%s", config.GoDownloadDir, fi.Name(), archFi.Name()))
+				paths = append(paths, archPath)
 			}
 		}
 	}
@@ -186,35 +240,6 @@ func listGoVersions(cmd *cobra.Command, config *Config) error {
 	return nil
 }
 
-// downloadGoVersion downloads a specific Go version.
-func downloadGoVersion(cmd *cobra.Command, config *Config, version string) error {
-	fmt.Println("= Downloading version from official resource =")
-
-	arch, _ := cmd.Flags().GetString("arch")
-	archiveName := fmt.Sprintf("go%s.%s.tar.gz", version, arch)
-	tmpArchivePath := fmt.Sprintf("/tmp/%s", archiveName)
-	targetDir := fmt.Sprintf("%s/%s/%s", config.GoDownloadDir, version, arch)
-
-	if err := os.MkdirAll(targetDir, 0755); err != nil {
-		return fmt.Errorf("create directory: %w", err)
-	}
-
-	if err := sh.Run("wget", "-O", tmpArchivePath, fmt.Sprintf("https://go.dev/dl/%s", archiveName)); err != nil {
-		return fmt.Errorf("download archive: %w", err)
-	}
-
-	if err := sh.Run("tar", "-xzf", tmpArchivePath, "-C", targetDir); err != nil {
-		return fmt.Errorf("extract archive: %w", err)
-	}
-
-	if err := os.Remove(tmpArchivePath); err != nil {
-		log.Printf("Warning: failed to remove archive: %v", err)
-	}
-
-	fmt.Println("= Download finished successfully =")
-	return nil
-}
-
 // cleanGoVersions removes all downloaded Go versions.
 func cleanGoVersions(config *Config) error {
 	fmt.Printf("= Removing all Go versions from %s =\n", config.GoDownloadDir)
@@ -235,11 +260,11 @@ func cleanGoVersions(config *Config) error {
 	}
 
 	for _, fi := range dir {
-		if !fi.IsDir() {
+		if !fi.IsDir() || isReservedDirName(fi.Name()) {
 			continue
 		}
 
-		versionDir := fmt.Sprintf("%s/%s", config.GoDownloadDir, fi.Name())
+		versionDir := filepath.Join(config.GoDownloadDir, fi.Name())
 		fmt.Printf("Removing version: %s\n", fi.Name())
 		if err := os.RemoveAll(versionDir); err != nil {
 			log.Printf("Warning: failed to remove %s: %v", versionDir, err)
@@ -247,120 +272,7 @@ func cleanGoVersions(config *Config) error {
 	}
 
 	fmt.Println("= Cleanup completed =")
-	return nil
-}
-
-// switchGoVersion switches to a specific Go version.
-func switchGoVersion(cmd *cobra.Command, config *Config, versionOrNumber string) error {
-	var (
-		version    string
-		arch       string
-		versionDir string
-	)
-
-	dir, err := os.ReadDir(config.GoDownloadDir)
-	if err != nil {
-		return fmt.Errorf("read directory: %w", err)
-	}
-
-	var (
-		versions      []string
-		architectures []string
-		paths         []string
-	)
-
-	for _, fi := range dir {
-		if !fi.IsDir() {
-			continue
-		}
-
-		versionDir := fmt.Sprintf("%s/%s", config.GoDownloadDir, fi.Name())
-		archDirs, err := os.ReadDir(versionDir)
-		if err != nil {
-			log.Printf("Error reading version directory %s: %v", fi.Name(), err)
-			continue
-		}
-
-		for _, archFi := range archDirs {
-			if archFi.IsDir() {
-				versions = append(versions, fi.Name())
-				architectures = append(architectures, archFi.Name())
-				paths = append(paths, fmt.Sprintf("%s/%s/%s", config.GoDownloadDir, fi.Name(), archFi.Name()))
-			}
-		}
-	}
-
-	if len(versions) == 0 {
-		return fmt.Errorf("no Go versions found")
-	}
-
-	if num, err := strconv.Atoi(versionOrNumber); err == nil {
-		if num < 1 || num > len(versions) {
-			return fmt.Errorf("invalid number. Please choose a number between 1 and %d", len(versions))
-		}
-
-		version = versions[num-1]
-		arch = architectures[num-1]
-		versionDir = paths[num-1]
-	} else {
-		arch, _ = cmd.Flags().GetString("arch")
-		version = versionOrNumber
-		versionDir = fmt.Sprintf("%s/%s/%s", config.GoDownloadDir, version, arch)
-	}
-
-	if _, err := os.Stat(versionDir); os.IsNotExist(err) {
-		return fmt.Errorf("Go version %s for architecture %s is not installed", version, arch)
-	}
-
-	fmt.Printf("= Switching to Go version %s for architecture %s =\n", version, arch)
-
-	goBinPath := fmt.Sprintf("%s/go/bin", versionDir)
-	goPath := fmt.Sprintf("%s/workspace", versionDir)
-	goRoot := fmt.Sprintf("%s/go", versionDir)
-
-	if err := os.MkdirAll(goPath, 0755); err != nil {
-		return fmt.Errorf("create workspace directory: %w", err)
-	}
-
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("get home directory: %w", err)
-	}
-
-	profilePath := fmt.Sprintf("%s/.profile", homeDir)
-	profileContent, err := os.ReadFile(profilePath)
-	if err != nil {
-		return fmt.Errorf("read profile: %w", err)
-	}
-
-	profileLines := strings.Split(string(profileContent), "\n")
-	var newProfileLines []string
-
-	for _, line := range profileLines {
-		if !strings.Contains(line, "export PATH=$PATH:") &&
-			!strings.Contains(line, "export GOPATH=") &&
-			!strings.Contains(line, "export GOROOT=") {
-			newProfileLines = append(newProfileLines, line)
-		}
-	}
-
-	newProfileLines = append(newProfileLines, "")
-	newProfileLines = append(newProfileLines, "# Go environment variables")
-	newProfileLines = append(newProfileLines, fmt.Sprintf("export PATH=$PATH:%s", goBinPath))
-	newProfileLines = append(newProfileLines, fmt.Sprintf("export GOPATH=%s", goPath))
-	newProfileLines = append(newProfileLines, fmt.Sprintf("export GOROOT=%s", goRoot))
-
-	if err := os.WriteFile(profilePath, []byte(strings.Join(newProfileLines, "\n")), 0644); err != nil {
-		return fmt.Errorf("write profile: %w", err)
-	}
-
-	fmt.Println("= Successfully switched to Go version", version, "=")
-	fmt.Println("PATH now includes:", goBinPath)
-	fmt.Println("GOPATH is set to:", goPath)
-	fmt.Println("GOROOT is set to:", goRoot)
-	fmt.Println("Changes have been written to", profilePath)
-	fmt.Println("Please log out and log back in for changes to take effect")
-
+	fmt.Println("Note: cached archives remain in the content-addressed store; run `go-switcher cache gc` to reclaim disk space")
 	return nil
 }
 
@@ -370,9 +282,14 @@ func main() {
 
 	rootCmd.AddCommand(
 		NewListCommand(config).Command,
+		NewListRemoteCommand(config).Command,
 		NewDownloadCommand(config).Command,
 		NewCleanCommand(config).Command,
 		NewSwitchCommand(config).Command,
+		NewUseCommand(config).Command,
+		NewShellCommand(config).Command,
+		NewBuildCommand(config).Command,
+		NewCacheCommand(config).Command,
 	)
 
 	if err := rootCmd.Execute(); err != nil {
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// progressWriter renders a simple textual progress bar to stderr as bytes
+// flow through it. It implements io.Writer so it can be composed with
+// io.TeeReader/io.MultiWriter alongside hashing.
+type progressWriter struct {
+	label   string
+	total   int64
+	written int64
+}
+
+// newProgressWriter creates a progress writer that will report progress
+// toward total, starting from startAt already-written bytes (nonzero when
+// resuming a partial download).
+func newProgressWriter(label string, total, startAt int64) *progressWriter {
+	return &progressWriter{label: label, total: total, written: startAt}
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	p.written += int64(len(b))
+
+	if p.total <= 0 {
+		fmt.Fprintf(os.Stderr, "\r%s: %s", p.label, formatBytes(p.written))
+		return len(b), nil
+	}
+
+	pct := float64(p.written) / float64(p.total) * 100
+	fmt.Fprintf(os.Stderr, "\r%s: %s/%s (%.1f%%)", p.label, formatBytes(p.written), formatBytes(p.total), pct)
+
+	return len(b), nil
+}
+
+func (p *progressWriter) done() {
+	fmt.Fprintln(os.Stderr)
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+var _ io.Writer = (*progressWriter)(nil)
@@ -0,0 +1,136 @@
+// Package releases fetches and queries the official Go release index
+// published at https://go.dev/dl/?mode=json, the same source golang.org/dl
+// uses to resolve versions to downloadable artifacts. It is shared by the
+// download and list-remote commands so they agree on what's available and
+// what it's named; switch does not consult it.
+package releases
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// IndexURL is the official Go release index endpoint.
+const IndexURL = "https://go.dev/dl/?mode=json"
+
+// Release describes a single Go release as reported by the release index.
+type Release struct {
+	Version string `json:"version"`
+	Stable  bool   `json:"stable"`
+	Files   []File `json:"files"`
+}
+
+// File describes a single downloadable artifact belonging to a Release.
+type File struct {
+	Filename string `json:"filename"`
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	Version  string `json:"version"`
+	SHA256   string `json:"sha256"`
+	Size     int64  `json:"size"`
+	Kind     string `json:"kind"`
+}
+
+// Fetch retrieves the list of Go releases from go.dev/dl. When includeAll
+// is true, unstable/archived releases are included as well as the current
+// stable ones.
+func Fetch(includeAll bool) ([]Release, error) {
+	url := IndexURL
+	if includeAll {
+		url += "&include=all"
+	}
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("fetch release index: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch release index: unexpected status %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read release index: %w", err)
+	}
+
+	var list []Release
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("parse release index: %w", err)
+	}
+
+	return list, nil
+}
+
+// NormalizeVersion ensures version carries the "go" prefix used by the
+// release index (e.g. "1.22.3" -> "go1.22.3").
+func NormalizeVersion(version string) string {
+	if strings.HasPrefix(version, "go") {
+		return version
+	}
+	return "go" + version
+}
+
+// FindFile looks up the archive matching version/os/arch among releases.
+// version may be given with or without the leading "go" prefix.
+func FindFile(list []Release, version, goos, goarch string) (*Release, *File, error) {
+	version = NormalizeVersion(version)
+
+	for i := range list {
+		release := &list[i]
+		if release.Version != version {
+			continue
+		}
+
+		for j := range release.Files {
+			file := &release.Files[j]
+			if file.OS == goos && file.Arch == goarch && file.Kind == "archive" {
+				return release, file, nil
+			}
+		}
+
+		return nil, nil, fmt.Errorf("no archive found for %s/%s in release %s", goos, goarch, version)
+	}
+
+	return nil, nil, fmt.Errorf("release %s not found", version)
+}
+
+// Filter returns the releases matching the given criteria, keeping only
+// files with Kind == "archive". An empty goos or goarch matches any;
+// stableOnly, when set, restricts to release.Stable == true.
+func Filter(list []Release, goos, goarch string, stableOnly bool) []Release {
+	var out []Release
+
+	for _, release := range list {
+		if stableOnly && !release.Stable {
+			continue
+		}
+
+		var files []File
+		for _, file := range release.Files {
+			if file.Kind != "archive" {
+				continue
+			}
+			if goos != "" && file.OS != goos {
+				continue
+			}
+			if goarch != "" && file.Arch != goarch {
+				continue
+			}
+			files = append(files, file)
+		}
+
+		if len(files) == 0 {
+			continue
+		}
+
+		release.Files = files
+		out = append(out, release)
+	}
+
+	return out
+}
@@ -0,0 +1,52 @@
+package releases
+
+import "testing"
+
+func testList() []Release {
+	return []Release{
+		{
+			Version: "go1.22.3",
+			Stable:  true,
+			Files: []File{
+				{Filename: "go1.22.3.linux-amd64.tar.gz", OS: "linux", Arch: "amd64", Version: "go1.22.3", SHA256: "abc", Kind: "archive"},
+				{Filename: "go1.22.3.darwin-arm64.tar.gz", OS: "darwin", Arch: "arm64", Version: "go1.22.3", SHA256: "def", Kind: "archive"},
+				{Filename: "go1.22.3.src.tar.gz", Kind: "source"},
+			},
+		},
+	}
+}
+
+func TestFindFile(t *testing.T) {
+	list := testList()
+
+	cases := []struct {
+		name    string
+		version string
+		goos    string
+		goarch  string
+		wantErr bool
+	}{
+		{name: "bare version", version: "1.22.3", goos: "linux", goarch: "amd64"},
+		{name: "go-prefixed version", version: "go1.22.3", goos: "darwin", goarch: "arm64"},
+		{name: "unknown version", version: "1.99.0", goos: "linux", goarch: "amd64", wantErr: true},
+		{name: "unknown arch", version: "1.22.3", goos: "windows", goarch: "amd64", wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, file, err := FindFile(list, c.version, c.goos, c.goarch)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("FindFile(%q, %q, %q) = %+v, want error", c.version, c.goos, c.goarch, file)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindFile(%q, %q, %q) returned unexpected error: %v", c.version, c.goos, c.goarch, err)
+			}
+			if file.OS != c.goos || file.Arch != c.goarch {
+				t.Errorf("FindFile(%q, %q, %q) = %+v, want os/arch %s/%s", c.version, c.goos, c.goarch, file, c.goos, c.goarch)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+// NewShellCommand creates the parent "shell" command, which groups
+// shell-integration subcommands.
+func NewShellCommand(config *Config) *Command {
+	cmd := &Command{
+		Command: &cobra.Command{
+			Use:   "shell",
+			Short: "Shell integration commands",
+			Long:  "Commands that wire go-switcher into your interactive shell",
+		},
+		config: config,
+	}
+
+	cmd.AddCommand(NewShellInitCommand(config).Command)
+
+	return cmd
+}
+
+// NewShellInitCommand creates the "shell init" command.
+func NewShellInitCommand(config *Config) *Command {
+	cmd := &Command{
+		Command: &cobra.Command{
+			Use:   "init [bash|zsh|fish|powershell]",
+			Short: "Print shell integration code for the given shell",
+			Long: "Print a shell function, in the style of `pyenv init`/`rbenv init`, that wraps go-switcher so " +
+				"`switch`/`use` take effect in the current shell session without editing a profile and logging back in. " +
+				"Add `eval \"$(go-switcher shell init bash)\"` (or the equivalent for your shell) to your shell's startup file.",
+			Args: cobra.ExactArgs(1),
+		},
+		config: config,
+	}
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		script, err := shellInitScript(args[0], config)
+		if err != nil {
+			log.Fatalf("Failed to generate shell integration: %v", err)
+		}
+		fmt.Println(script)
+	}
+
+	return cmd
+}
+
+// shellInitScript renders the shell integration snippet for shell.
+func shellInitScript(shell string, config *Config) (string, error) {
+	shims := shimDir(config)
+
+	switch shell {
+	case "bash", "zsh":
+		return fmt.Sprintf(`export PATH="%s:$PATH"
+go-switcher() {
+  case "$1" in
+    switch|use)
+      local __goswitcher_env
+      __goswitcher_env="$(command go-switcher "$@" --print-env)" || return $?
+      eval "$(echo "$__goswitcher_env" | sed 's/^/export /')"
+      ;;
+    *)
+      command go-switcher "$@"
+      ;;
+  esac
+}
+`, shims), nil
+
+	case "fish":
+		return fmt.Sprintf(`set -gx PATH %s $PATH
+function go-switcher
+  switch $argv[1]
+    case switch use
+      set -l __goswitcher_env (command go-switcher $argv --print-env)
+      for line in $__goswitcher_env
+        set -l kv (string split -m 1 = -- $line)
+        set -gx $kv[1] $kv[2]
+      end
+    case '*'
+      command go-switcher $argv
+  end
+end
+`, shims), nil
+
+	case "powershell":
+		return fmt.Sprintf(`$env:PATH = "%s;" + $env:PATH
+function go-switcher {
+  param([Parameter(ValueFromRemainingArguments=$true)]$Args)
+  if ($Args[0] -eq 'switch' -or $Args[0] -eq 'use') {
+    $envOut = & go-switcher.exe @Args --print-env
+    foreach ($line in $envOut) {
+      $parts = $line -split '=', 2
+      Set-Item -Path "Env:$($parts[0])" -Value $parts[1]
+    }
+  } else {
+    & go-switcher.exe @Args
+  }
+}
+`, shims), nil
+
+	default:
+		return "", fmt.Errorf("unsupported shell %q, expected bash, zsh, fish, or powershell", shell)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// shimmedTools are the go-switcher-managed executables installed into the
+// shim directory. Extend this list as `go-switcher` learns to wrap more
+// of the Go toolchain (e.g. gopls).
+var shimmedTools = []string{"go", "gofmt"}
+
+// shimsDirName is the top-level directory, alongside version directories,
+// holding the shim scripts. It's excluded when walking config.GoDownloadDir
+// for installed versions.
+const shimsDirName = "shims"
+
+// shimDir returns the directory containing go-switcher's shims: thin
+// scripts that re-exec into whichever Go version is currently active,
+// so switching versions never requires editing a shell profile.
+func shimDir(config *Config) string {
+	return filepath.Join(config.GoDownloadDir, shimsDirName)
+}
+
+// shimCurrentFile records the bin directory of the currently active Go
+// version; every shim reads it at exec time to find the real binary.
+func shimCurrentFile(config *Config) string {
+	return filepath.Join(shimDir(config), "CURRENT")
+}
+
+// updateShimPointer installs the shim scripts (if not already present) and
+// points them at binPath.
+func updateShimPointer(config *Config, binPath string) error {
+	dir := shimDir(config)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("create shim directory: %w", err)
+	}
+
+	for _, tool := range shimmedTools {
+		if err := writeShim(dir, tool); err != nil {
+			return err
+		}
+	}
+
+	if err := os.WriteFile(shimCurrentFile(config), []byte(binPath), 0644); err != nil {
+		return fmt.Errorf("write shim pointer: %w", err)
+	}
+
+	return nil
+}
+
+// writeShim writes a single shim script for tool into dir, unless it's
+// already present.
+func writeShim(dir, tool string) error {
+	name := tool
+	script := fmt.Sprintf("#!/bin/sh\nexec \"$(cat %q)/%s\" \"$@\"\n", filepath.Join(dir, "CURRENT"), tool)
+
+	if runtime.GOOS == "windows" {
+		name = tool + ".cmd"
+		script = fmt.Sprintf("@echo off\r\nset /p GOSWITCHER_BIN=<%q\r\n\"%%GOSWITCHER_BIN%%\\%s.exe\" %%*\r\n", filepath.Join(dir, "CURRENT"), tool)
+	}
+
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		return fmt.Errorf("write shim %s: %w", name, err)
+	}
+
+	return nil
+}
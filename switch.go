@@ -0,0 +1,275 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// goPaths bundles the derived GOROOT/GOPATH/PATH entries for an installed
+// Go version, ready to either be written to the shell profile or printed.
+type goPaths struct {
+	config     *Config
+	version    string
+	arch       string
+	binPath    string
+	goPath     string
+	goRoot     string
+	versionDir string
+}
+
+// resolveGoPaths locates an installed version directory and derives its
+// GOROOT/GOPATH/PATH layout.
+func resolveGoPaths(config *Config, version, arch string) (*goPaths, error) {
+	versionDir := filepath.Join(config.GoDownloadDir, version, arch)
+
+	if _, err := os.Stat(versionDir); os.IsNotExist(err) {
+		return nil, fmt.Errorf("Go version %s for architecture %s is not installed", version, arch)
+	}
+
+	return &goPaths{
+		config:     config,
+		version:    version,
+		arch:       arch,
+		binPath:    filepath.Join(versionDir, "go", "bin"),
+		goPath:     filepath.Join(versionDir, "workspace"),
+		goRoot:     filepath.Join(versionDir, "go"),
+		versionDir: versionDir,
+	}, nil
+}
+
+// activate makes p the active Go version: it refreshes the shim directory
+// (see shimDir) so its shims re-exec into p's bin directory, then either prints an env block
+// for the caller's shell to eval (print == true) or rewrites the user's
+// shell profile.
+func (p *goPaths) activate(print bool) error {
+	if err := os.MkdirAll(p.goPath, 0755); err != nil {
+		return fmt.Errorf("create workspace directory: %w", err)
+	}
+
+	if err := updateShimPointer(p.config, p.binPath); err != nil {
+		return fmt.Errorf("update shims: %w", err)
+	}
+
+	if print {
+		fmt.Printf("GOROOT=%s\n", p.goRoot)
+		fmt.Printf("GOPATH=%s\n", p.goPath)
+		fmt.Printf("PATH=%s:$PATH\n", p.binPath)
+		return nil
+	}
+
+	return p.writeProfile()
+}
+
+// env returns the GOROOT/GOPATH/PATH environment block for p, with PATH
+// prepended with p's bin directory so it's ready to export as-is.
+func (p *goPaths) env() map[string]string {
+	return map[string]string{
+		"GOROOT": p.goRoot,
+		"GOPATH": p.goPath,
+		"PATH":   p.binPath + string(os.PathListSeparator) + os.Getenv("PATH"),
+	}
+}
+
+// printEnv refreshes the shim pointer (the same way activate
+// does) and writes p's environment block to stdout as KEY=VALUE lines
+// (format == "json" writes a JSON object instead), for a shell wrapper to
+// capture and export into the live session.
+func (p *goPaths) printEnv(format string) error {
+	if err := updateShimPointer(p.config, p.binPath); err != nil {
+		return fmt.Errorf("update shims: %w", err)
+	}
+
+	env := p.env()
+
+	if format == "json" {
+		data, err := json.MarshalIndent(env, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal env: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	for _, key := range []string{"GOROOT", "GOPATH", "PATH"} {
+		fmt.Printf("%s=%s\n", key, env[key])
+	}
+
+	return nil
+}
+
+// writeProfile persists this version's GOROOT/GOPATH/PATH to the platform's
+// shell profile: ~/.profile everywhere except Windows, which has no such
+// file and uses a PowerShell profile script instead.
+func (p *goPaths) writeProfile() error {
+	if runtime.GOOS == "windows" {
+		return p.writePowerShellProfile()
+	}
+	return p.writeUnixProfile()
+}
+
+func (p *goPaths) writeUnixProfile() error {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("get home directory: %w", err)
+	}
+
+	profilePath := filepath.Join(homeDir, ".profile")
+	profileContent, err := os.ReadFile(profilePath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("read profile: %w", err)
+	}
+
+	profileLines := strings.Split(string(profileContent), "\n")
+	var newProfileLines []string
+
+	for _, line := range profileLines {
+		if !strings.Contains(line, "export PATH=$PATH:") &&
+			!strings.Contains(line, "export GOPATH=") &&
+			!strings.Contains(line, "export GOROOT=") {
+			newProfileLines = append(newProfileLines, line)
+		}
+	}
+
+	newProfileLines = append(newProfileLines, "")
+	newProfileLines = append(newProfileLines, "# Go environment variables")
+	newProfileLines = append(newProfileLines, fmt.Sprintf("export PATH=$PATH:%s", p.binPath))
+	newProfileLines = append(newProfileLines, fmt.Sprintf("export GOPATH=%s", p.goPath))
+	newProfileLines = append(newProfileLines, fmt.Sprintf("export GOROOT=%s", p.goRoot))
+
+	if err := os.WriteFile(profilePath, []byte(strings.Join(newProfileLines, "\n")), 0644); err != nil {
+		return fmt.Errorf("write profile: %w", err)
+	}
+
+	p.printSummary(profilePath)
+	return nil
+}
+
+func (p *goPaths) writePowerShellProfile() error {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("get home directory: %w", err)
+	}
+
+	profilePath := filepath.Join(homeDir, "Documents", "WindowsPowerShell", "profile.ps1")
+	if err := os.MkdirAll(filepath.Dir(profilePath), 0755); err != nil {
+		return fmt.Errorf("create profile directory: %w", err)
+	}
+
+	profileContent, err := os.ReadFile(profilePath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("read profile: %w", err)
+	}
+
+	profileLines := strings.Split(string(profileContent), "\n")
+	var newProfileLines []string
+
+	for _, line := range profileLines {
+		if !strings.Contains(line, `$env:PATH = "`) &&
+			!strings.Contains(line, "$env:GOPATH =") &&
+			!strings.Contains(line, "$env:GOROOT =") {
+			newProfileLines = append(newProfileLines, line)
+		}
+	}
+
+	newProfileLines = append(newProfileLines, "")
+	newProfileLines = append(newProfileLines, "# Go environment variables")
+	newProfileLines = append(newProfileLines, fmt.Sprintf(`$env:PATH = "%s;" + $env:PATH`, p.binPath))
+	newProfileLines = append(newProfileLines, fmt.Sprintf("$env:GOPATH = %q", p.goPath))
+	newProfileLines = append(newProfileLines, fmt.Sprintf("$env:GOROOT = %q", p.goRoot))
+
+	if err := os.WriteFile(profilePath, []byte(strings.Join(newProfileLines, "\n")), 0644); err != nil {
+		return fmt.Errorf("write profile: %w", err)
+	}
+
+	p.printSummary(profilePath)
+	return nil
+}
+
+func (p *goPaths) printSummary(profilePath string) {
+	fmt.Println("= Successfully switched to Go version", p.version, "=")
+	fmt.Println("PATH now includes:", p.binPath)
+	fmt.Println("GOPATH is set to:", p.goPath)
+	fmt.Println("GOROOT is set to:", p.goRoot)
+	fmt.Println("Changes have been written to", profilePath)
+	fmt.Println("Restart your shell (or open a new PowerShell session) for changes to take effect")
+}
+
+// switchGoVersion switches to a specific Go version, either addressed
+// directly (version string) or by its 1-based position in `list`'s output.
+func switchGoVersion(cmd *cobra.Command, config *Config, versionOrNumber string) error {
+	var (
+		version string
+		arch    string
+	)
+
+	dir, err := os.ReadDir(config.GoDownloadDir)
+	if err != nil {
+		return fmt.Errorf("read directory: %w", err)
+	}
+
+	var (
+		versions      []string
+		architectures []string
+	)
+
+	for _, fi := range dir {
+		if !fi.IsDir() || isReservedDirName(fi.Name()) {
+			continue
+		}
+
+		archDirs, err := os.ReadDir(filepath.Join(config.GoDownloadDir, fi.Name()))
+		if err != nil {
+			continue
+		}
+
+		for _, archFi := range archDirs {
+			if isDirOrLinkToDir(filepath.Join(config.GoDownloadDir, fi.Name(), archFi.Name())) {
+				versions = append(versions, fi.Name())
+				architectures = append(architectures, archFi.Name())
+			}
+		}
+	}
+
+	if len(versions) == 0 {
+		return fmt.Errorf("no Go versions found")
+	}
+
+	if num, err := strconv.Atoi(versionOrNumber); err == nil {
+		if num < 1 || num > len(versions) {
+			return fmt.Errorf("invalid number. Please choose a number between 1 and %d", len(versions))
+		}
+
+		version = versions[num-1]
+		arch = architectures[num-1]
+	} else {
+		flagArch, _ := cmd.Flags().GetString("arch")
+		arch = normalizeArch(flagArch)
+		version = versionOrNumber
+	}
+
+	print, _ := cmd.Flags().GetBool("print")
+	printEnv, _ := cmd.Flags().GetBool("print-env")
+	format, _ := cmd.Flags().GetString("format")
+
+	if !print && !printEnv {
+		fmt.Printf("= Switching to Go version %s for architecture %s =\n", version, arch)
+	}
+
+	paths, err := resolveGoPaths(config, version, arch)
+	if err != nil {
+		return err
+	}
+
+	if printEnv {
+		return paths.printEnv(format)
+	}
+
+	return paths.activate(print)
+}
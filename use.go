@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// goVersionFileName is the de-facto convention (popularized by nvm-style
+// tools) for pinning a project's Go version in a plain-text file.
+const goVersionFileName = ".go-version"
+
+// goModVersionPattern matches the "go 1.xx.y" (or "go 1.xx") directive in a
+// go.mod file.
+var goModVersionPattern = regexp.MustCompile(`^go\s+(\d+\.\d+(?:\.\d+)?)\s*$`)
+
+// NewUseCommand creates a new use command.
+func NewUseCommand(config *Config) *Command {
+	cmd := &Command{
+		Command: &cobra.Command{
+			Use:   "use",
+			Short: "Activate the Go version pinned by the current project",
+			Long:  "Walk upward from the current directory for a .go-version file or a go.mod `go` directive, and activate the matching installed toolchain",
+		},
+		config: config,
+	}
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		if err := useGoVersion(cmd, config); err != nil {
+			log.Fatalf("Failed to activate project Go version: %v", err)
+		}
+	}
+
+	cmd.Flags().String("arch", defaultArch(), "Architecture to activate (e.g., linux-amd64, darwin-arm64, windows-amd64)")
+	cmd.Flags().Bool("no-download", false, "Fail instead of downloading the pinned version if it isn't installed")
+	cmd.Flags().Bool("print", false, "Print GOROOT/GOPATH/PATH to stdout instead of writing the shell profile")
+	cmd.Flags().Bool("print-env", false, "Print a machine-readable env block (KEY=VALUE lines, or JSON with --format json) instead of writing the shell profile")
+	cmd.Flags().String("format", "env", "Format for --print-env: env or json")
+
+	return cmd
+}
+
+// useGoVersion resolves the Go version pinned by the current project and
+// activates it, downloading it first if necessary and permitted.
+func useGoVersion(cmd *cobra.Command, config *Config) error {
+	arch, _ := cmd.Flags().GetString("arch")
+	arch = normalizeArch(arch)
+	noDownload, _ := cmd.Flags().GetBool("no-download")
+	print, _ := cmd.Flags().GetBool("print")
+	printEnv, _ := cmd.Flags().GetBool("print-env")
+	format, _ := cmd.Flags().GetString("format")
+	quiet := print || printEnv
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	version, source, err := findProjectGoVersion(cwd)
+	if err != nil {
+		return err
+	}
+
+	if !quiet {
+		fmt.Printf("= Found Go version %s in %s =\n", version, source)
+	}
+
+	if _, err := resolveGoPaths(config, version, arch); err != nil {
+		if noDownload {
+			return err
+		}
+
+		if !quiet {
+			fmt.Printf("= Go %s is not installed, downloading =\n", version)
+		}
+
+		if err := downloadGoVersion(cmd, config, version); err != nil {
+			return fmt.Errorf("download pinned Go version: %w", err)
+		}
+	}
+
+	paths, err := resolveGoPaths(config, version, arch)
+	if err != nil {
+		return err
+	}
+
+	if !quiet {
+		fmt.Printf("= Switching to Go version %s for architecture %s =\n", version, arch)
+	}
+
+	if printEnv {
+		return paths.printEnv(format)
+	}
+
+	return paths.activate(print)
+}
+
+// findProjectGoVersion walks upward from dir looking for a .go-version file
+// or a go.mod `go` directive, returning the pinned version and a
+// human-readable description of where it was found.
+func findProjectGoVersion(dir string) (version, source string, err error) {
+	for {
+		versionFile := filepath.Join(dir, goVersionFileName)
+		if data, err := os.ReadFile(versionFile); err == nil {
+			return strings.TrimSpace(string(data)), versionFile, nil
+		}
+
+		goModPath := filepath.Join(dir, "go.mod")
+		if version, ok := readGoModVersion(goModPath); ok {
+			return version, goModPath, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	return "", "", fmt.Errorf("no %s or go.mod with a go directive found in this directory or any parent", goVersionFileName)
+}
+
+// readGoModVersion extracts the `go 1.xx.y` directive from a go.mod file.
+func readGoModVersion(goModPath string) (string, bool) {
+	f, err := os.Open(goModPath)
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if match := goModVersionPattern.FindStringSubmatch(scanner.Text()); match != nil {
+			return match[1], true
+		}
+	}
+
+	return "", false
+}